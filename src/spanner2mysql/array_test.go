@@ -0,0 +1,173 @@
+package spanner2mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestMysqlMapTypeArray(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	for _, tag := range []types.ScalarColumnTypeTag{types.Int64, types.String, types.Timestamp} {
+		got, err := d.MapType(types.ColumnType{TypeTag: tag, IsArray: true}, opts)
+		if err != nil {
+			t.Fatalf("MapType(ARRAY<%v>) returned an error: %v", tag, err)
+		}
+		if want := "JSON"; got != want {
+			t.Errorf("MapType(ARRAY<%v>) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestPostgresMapTypeArray(t *testing.T) {
+	d := postgresDialect{}
+	opts := &Options{}
+
+	cases := []struct {
+		tag  types.ScalarColumnTypeTag
+		want string
+	}{
+		{types.Int64, "BIGINT[]"},
+		{types.String, "VARCHAR[]"},
+		{types.Timestamp, "TIMESTAMPTZ[]"},
+	}
+
+	for _, c := range cases {
+		got, err := d.MapType(types.ColumnType{TypeTag: c.tag, IsArray: true}, opts)
+		if err != nil {
+			t.Fatalf("MapType(ARRAY<%v>) returned an error: %v", c.tag, err)
+		}
+		if got != c.want {
+			t.Errorf("MapType(ARRAY<%v>) = %q, want %q", c.tag, got, c.want)
+		}
+	}
+}
+
+func TestMysqlArrayCastType(t *testing.T) {
+	cases := []struct {
+		elem types.ColumnType
+		want string
+	}{
+		{types.ColumnType{TypeTag: types.Bool}, "UNSIGNED"},
+		{types.ColumnType{TypeTag: types.Int64}, "SIGNED"},
+		{types.ColumnType{TypeTag: types.Date}, "DATE"},
+		{types.ColumnType{TypeTag: types.Timestamp}, "DATETIME"},
+		{types.ColumnType{TypeTag: types.Float64}, "DECIMAL(65, 30)"},
+		{types.ColumnType{TypeTag: types.Bytes, Length: 16}, "BINARY(16)"},
+		{types.ColumnType{TypeTag: types.String, Length: 36}, "CHAR(36)"},
+		// Unset/over-long lengths fall back to 255, not an unbounded type.
+		{types.ColumnType{TypeTag: types.String}, "CHAR(255)"},
+		{types.ColumnType{TypeTag: types.Bytes, Length: 1000}, "BINARY(255)"},
+	}
+
+	for _, c := range cases {
+		if got := mysqlArrayCastType(c.elem); got != c.want {
+			t.Errorf("mysqlArrayCastType(%+v) = %q, want %q", c.elem, got, c.want)
+		}
+	}
+}
+
+func TestMysqlRenderIndexArrayColumnUsesCast(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	table := types.CreateTableStatement{
+		TableName: "events",
+		Columns: []types.Column{
+			{Name: "tags", Type: types.ColumnType{TypeTag: types.Int64, IsArray: true}},
+		},
+	}
+	indexes := []types.CreateIndexStatement{
+		{TableName: "events", IndexName: "idx_tags", Keys: []types.Key{{Name: "tags"}}, Unique: false},
+	}
+
+	defs, _, err := d.RenderIndex(table, indexes, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(defs) != 1 {
+		t.Fatalf("expected 1 index def, got %d: %v", len(defs), defs)
+	}
+	if want := "(CAST(`tags`->'$' AS SIGNED ARRAY))"; !strings.Contains(defs[0], want) {
+		t.Errorf("index def = %q, want it to contain %q", defs[0], want)
+	}
+}
+
+func TestMysqlArrayCheckConstraintStrict(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{Strict: true}
+
+	got, err := d.ArrayCheckConstraint(types.ColumnType{TypeTag: types.Int64}, "`tags`", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `CHECK (JSON_SCHEMA_VALID('{"type": "array", "items": {"type": "integer"}}', ` + "`tags`" + `))`; got != want {
+		t.Errorf("ArrayCheckConstraint = %q, want %q", got, want)
+	}
+}
+
+func TestMysqlArrayCheckConstraintNonStrict(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{Strict: false}
+
+	got, err := d.ArrayCheckConstraint(types.ColumnType{TypeTag: types.Int64}, "`tags`", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("ArrayCheckConstraint under non-Strict = %q, want no constraint (plain JSON)", got)
+	}
+}
+
+func TestPostgresArrayCheckConstraintAlwaysNoop(t *testing.T) {
+	d := postgresDialect{}
+
+	for _, strict := range []bool{true, false} {
+		got, err := d.ArrayCheckConstraint(types.ColumnType{TypeTag: types.Int64}, `"tags"`, &Options{Strict: strict})
+		if err != nil {
+			t.Fatalf("unexpected error (Strict=%v): %v", strict, err)
+		}
+		if got != "" {
+			t.Errorf("ArrayCheckConstraint (Strict=%v) = %q, want \"\" (Postgres has a native array type)", strict, got)
+		}
+	}
+}
+
+func TestGetColumnsArrayColumnStrict(t *testing.T) {
+	d := mysqlDialect{}
+	ct := types.CreateTableStatement{
+		TableName: "events",
+		Columns: []types.Column{
+			{Name: "tags", Type: types.ColumnType{TypeTag: types.Int64, IsArray: true}},
+		},
+	}
+
+	cols, err := getColumns(d, ct, &Options{Strict: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(cols[0], "JSON_SCHEMA_VALID") {
+		t.Errorf("Strict array column should carry a JSON_SCHEMA_VALID CHECK, got: %q", cols[0])
+	}
+}
+
+func TestGetColumnsArrayColumnNonStrict(t *testing.T) {
+	d := mysqlDialect{}
+	ct := types.CreateTableStatement{
+		TableName: "events",
+		Columns: []types.Column{
+			{Name: "tags", Type: types.ColumnType{TypeTag: types.Int64, IsArray: true}},
+		},
+	}
+
+	cols, err := getColumns(d, ct, &Options{Strict: false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(cols[0], "CHECK") {
+		t.Errorf("non-Strict array column should fall back to plain JSON, got: %q", cols[0])
+	}
+}
@@ -2,14 +2,13 @@ package spanner2mysql
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/syucream/spar/src/types"
 )
 
 const (
-	// header text
-	header = "-- Auto-generated by jackup. DO NOT EDIT!\n--\n\n"
 	// MySQL requires fixed size index
 	pseudoKeyLength = 255
 )
@@ -18,56 +17,155 @@ var (
 	invalidInterleaveErr = fmt.Errorf("Invalid interleave")
 	invalidSpannerErr    = fmt.Errorf("Invalid spanner type")
 	invalidKeyErr        = fmt.Errorf("Invalid key")
-
-	toMysqlType = map[types.ScalarColumnTypeTag]string{
-		types.Bool:      "TINYINT(1)",
-		types.Int64:     "BIGINT",
-		types.Float64:   "DOUBLE",
-		types.String:    "VARCHAR",
-		types.Bytes:     "BLOB",
-		types.Date:      "DATE",
-		types.Timestamp: "TIMESTAMP",
-	}
 )
 
-type Spanner2MysqlConverter struct {
+// Options controls how Convert renders DDL for a dialect. Not every field
+// applies to every dialect; unused ones are simply ignored.
+type Options struct {
 	Strict               bool
 	AllowConvertString   bool
 	AllowShotenIndexName bool
+	// RenameReserved rewrites an identifier that collides with the target
+	// dialect's reserved words (by suffixing "_col") or length limit (by
+	// truncating it) instead of merely warning about it.
+	RenameReserved bool
+	// CustomTypes overrides a dialect's built-in type mapping, keyed by
+	// either "table.column" or a bare Spanner scalar type name (e.g.
+	// "STRING"). Populate it with LoadCustomTypesFile.
+	CustomTypes map[string]string
+	// CustomTypePatterns overrides the type of any column whose name matches
+	// the pattern's regexp, checked before CustomTypes' Spanner-type entries.
+	CustomTypePatterns []customTypePattern
+	// NamingConvention rewrites table, column, primary-key, foreign-key, and
+	// index identifiers before rendering them.
+	NamingConvention NamingConvention
+}
+
+// Spanner2MysqlConverter is the original MySQL-only entry point, kept around
+// for backward compatibility. New code should prefer Convert with "mysql" as
+// the dialect name.
+type Spanner2MysqlConverter struct {
+	Options
+}
+
+func (c *Spanner2MysqlConverter) Convert(statements *types.DDStatements) (string, error) {
+	return Convert(statements, "mysql", &c.Options)
 }
 
-func (c *Spanner2MysqlConverter) getMysqlType(t types.ColumnType) (string, error) {
-	convertedType := ""
-
-	if v, ok := toMysqlType[t.TypeTag]; ok {
-		convertedType = v
-		// Replace too big VARCHAR to TEXT or append length attribute for VARCHAR
-		if c.AllowConvertString && t.TypeTag == types.String {
-			if t.Length > 256 {
-				convertedType = "TEXT"
-			} else {
-				convertedType += fmt.Sprintf("%d", t.Length)
+// Convert transpiles Spanner DDL statements into the named dialect's DDL.
+func Convert(statements *types.DDStatements, dialectName string, opts *Options) (string, error) {
+	d, err := lookupDialect(dialectName)
+	if err != nil {
+		return "", err
+	}
+
+	statements = applyNamingConvention(statements, opts.NamingConvention)
+
+	converted := d.Header()
+
+	for _, ct := range statements.CreateTables {
+		tableIdent, err := quoteIdent(d, ct.TableName, opts)
+		if err != nil {
+			return "", err
+		}
+
+		converted += fmt.Sprintf("CREATE TABLE %s (\n", tableIdent)
+
+		defs, err := getColumns(d, ct, opts)
+		if err != nil {
+			return "", err
+		}
+
+		pk, err := d.RenderPrimaryKey(ct, opts)
+		if err != nil {
+			if err != invalidKeyErr {
+				return "", err
 			}
+		} else {
+			defs = append(defs, pk)
+		}
+
+		// Convert interleave to foreign key
+		relation, err := getRelation(d, ct, statements.CreateTables, opts)
+		if err != nil {
+			if err != invalidKeyErr {
+				return "", err
+			}
+		} else if relation != "" {
+			defs = append(defs, relation)
+		}
+
+		// Convert CreateIndex'es to INDEX(...) or UNIQUE(...)
+		idx, trailing, err := d.RenderIndex(ct, statements.CreateIndexes, opts)
+		if err != nil {
+			return "", err
+		}
+		defs = append(defs, idx...)
+
+		converted += strings.Join(defs, ",\n") + "\n);\n"
+
+		for _, stmt := range trailing {
+			converted += stmt + "\n"
 		}
-	} else {
-		return "", invalidSpannerErr
 	}
 
-	return convertedType, nil
+	return converted, nil
 }
 
-func (c *Spanner2MysqlConverter) getColumns(ct types.CreateTableStatement) ([]string, error) {
+// quoteIdent resolves name against d's reserved-word table and length limit
+// before quoting it. A collision is an error under Strict, a warning
+// otherwise; if opts.RenameReserved is set, the identifier is also rewritten
+// so the generated DDL is actually valid: a reserved word is suffixed with
+// "_col", and an over-length identifier is truncated to fit (suffixing it
+// instead would only make it longer).
+func quoteIdent(d Dialect, name string, opts *Options) (string, error) {
+	resolved := name
+
+	reserved := d.IsReserved(name)
+	tooLong := len(name) > d.MaxIdentLength()
+
+	if reserved || tooLong {
+		reason := "reserved word"
+		if tooLong {
+			reason = fmt.Sprintf("longer than %d chars", d.MaxIdentLength())
+		}
+
+		if opts.Strict {
+			return "", fmt.Errorf("jack: identifier %q collides with %s dialect (%s)", name, d.Name(), reason)
+		}
+
+		fmt.Fprintf(os.Stderr, "jack: warning: identifier %q collides with %s dialect (%s)\n", name, d.Name(), reason)
+
+		if opts.RenameReserved {
+			switch {
+			case tooLong:
+				resolved = name[:d.MaxIdentLength()]
+			case reserved:
+				resolved = name + "_col"
+				if len(resolved) > d.MaxIdentLength() {
+					resolved = resolved[:d.MaxIdentLength()]
+				}
+			}
+		}
+	}
+
+	return d.QuoteIdent(resolved), nil
+}
+
+func getColumns(d Dialect, ct types.CreateTableStatement, opts *Options) ([]string, error) {
 	var cols []string
 
 	for _, col := range ct.Columns {
-		convertedType, err := c.getMysqlType(col.Type)
+		convertedType, err := resolveColumnType(d, ct.TableName, col, opts)
 		if err != nil {
 			return []string{}, err
 		}
 
 		defaultValue := ""
-		// TIMESTAMP doesn't allow implicit default value
-		if convertedType == "TIMESTAMP" && col.NotNull {
+		// TIMESTAMP doesn't allow implicit default value. Checked against the
+		// Spanner source type rather than convertedType so this isn't
+		// MySQL-specific: it also applies to Postgres's TIMESTAMPTZ.
+		if col.Type.TypeTag == types.Timestamp && !col.Type.IsArray && col.NotNull {
 			defaultValue = "DEFAULT CURRENT_TIMESTAMP"
 		}
 
@@ -76,42 +174,41 @@ func (c *Spanner2MysqlConverter) getColumns(ct types.CreateTableStatement) ([]st
 			nullability = "NOT NULL"
 		}
 
-		cols = append(cols, fmt.Sprintf("  `%s` %s %s %s", col.Name, convertedType, nullability, defaultValue))
-	}
+		ident, err := quoteIdent(d, col.Name, opts)
+		if err != nil {
+			return []string{}, err
+		}
 
-	return cols, nil
-}
+		colDef := fmt.Sprintf("  %s %s %s %s", ident, convertedType, nullability, defaultValue)
 
-func (c *Spanner2MysqlConverter) getPrimaryKey(ct types.CreateTableStatement) (string, error) {
-	expectedLen := len(ct.PrimaryKeys)
-	keyNames := make([]string, 0, expectedLen)
+		if col.Type.IsArray {
+			constraint, err := d.ArrayCheckConstraint(col.Type, ident, opts)
+			if err != nil {
+				return []string{}, err
+			}
+			if constraint != "" {
+				colDef += " " + constraint
+			}
+		}
 
-	for _, pk := range ct.PrimaryKeys {
-		for _, col := range ct.Columns {
-			if col.Name == pk.Name {
-				// Check precondition
-				if !col.NotNull {
-					return "", invalidKeyErr
-				}
+		cols = append(cols, colDef)
+	}
 
-				kn := fmt.Sprintf("`%s`", pk.Name)
-				if mt, err := c.getMysqlType(col.Type); err == nil && (mt == "TEXT" || mt == "BLOB") {
-					kn += fmt.Sprintf("(%d)", pseudoKeyLength)
-				}
+	return cols, nil
+}
 
-				keyNames = append(keyNames, kn)
-			}
+// lookupColumn returns the column named name on table, if any.
+func lookupColumn(table types.CreateTableStatement, name string) (types.Column, bool) {
+	for _, col := range table.Columns {
+		if col.Name == name {
+			return col, true
 		}
 	}
 
-	if expectedLen == len(keyNames) {
-		return fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(keyNames, ", ")), nil
-	} else {
-		return "", invalidKeyErr
-	}
+	return types.Column{}, false
 }
 
-func (c *Spanner2MysqlConverter) getRelation(child types.CreateTableStatement, maybeParents []types.CreateTableStatement) (string, error) {
+func getRelation(d Dialect, child types.CreateTableStatement, maybeParents []types.CreateTableStatement, opts *Options) (string, error) {
 	// no relation
 	if child.Cluster.TableName == "" {
 		return "", nil
@@ -129,89 +226,29 @@ func (c *Spanner2MysqlConverter) getRelation(child types.CreateTableStatement, m
 		return "", invalidInterleaveErr
 	}
 
-	var keyCol *types.Column
-	for _, cc := range child.Columns {
-		for _, pc := range parent.Columns {
-			if cc.Name == pc.Name && cc.Type == pc.Type {
-				keyCol = &cc
-				break
-			}
-		}
-	}
-
-	if keyCol == nil {
-		return "", invalidInterleaveErr
-	}
-
-	// FOREIGN KEY TO TEXT or BLOB isn't supported
-	if mt, err := c.getMysqlType(keyCol.Type); err == nil || mt == "TEXT" || mt == "BLOB" {
-		return "", invalidKeyErr
-	}
-
-	return fmt.Sprintf("  FOREIGN KEY (`%s`) REFERENCES `%s` (`%s`)", keyCol.Name, parent.TableName, keyCol.Name), nil
-}
-
-func (c *Spanner2MysqlConverter) getIndexes(table types.CreateTableStatement, indexes []types.CreateIndexStatement) []string {
-	var strIndexes []string
-
-	for _, i := range indexes {
-		if table.TableName == i.TableName {
-			keys := make([]string, 0, len(i.Keys))
-			for _, k := range i.Keys {
-				keys = append(keys, fmt.Sprintf("`%s`", k.Name))
-			}
-
-			if i.Unique {
-				iname := i.IndexName
-				if c.AllowShotenIndexName && len(iname) > 255 {
-					iname = ""
-				}
-				strIndexes = append(strIndexes, fmt.Sprintf("  INDEX `%s` (%s)", iname, strings.Join(keys, ", ")))
-			} else {
-				strIndexes = append(strIndexes, fmt.Sprintf("  UNIQUE (%s)", strings.Join(keys, ", ")))
-			}
-
-		}
-	}
-
-	return strIndexes
-}
-
-func (c *Spanner2MysqlConverter) Convert(statements *types.DDStatements) (string, error) {
-	converted := ""
-
-	for _, ct := range statements.CreateTables {
-		converted += fmt.Sprintf("CREATE TABLE %s (\n", ct.TableName)
-
-		defs, err := c.getColumns(ct)
-		if err != nil {
-			return "", err
+	// Interleaved children must share the entire parent primary key prefix,
+	// not just a single column, so match it in full and in order.
+	keyCols := make([]types.Column, 0, len(parent.PrimaryKeys))
+	for _, pk := range parent.PrimaryKeys {
+		pc, ok := lookupColumn(*parent, pk.Name)
+		if !ok {
+			return "", invalidInterleaveErr
 		}
 
-		pk, err := c.getPrimaryKey(ct)
-		if err != nil {
-			if err != invalidKeyErr {
-				return "", err
+		var matched *types.Column
+		for _, cc := range child.Columns {
+			if cc.Name == pc.Name && cc.Type == pc.Type {
+				matched = &cc
+				break
 			}
-		} else {
-			defs = append(defs, pk)
 		}
 
-		// Convert interleave to foreign key
-		relation, err := c.getRelation(ct, statements.CreateTables)
-		if err != nil {
-			if err != invalidKeyErr {
-				return "", err
-			}
-		} else if relation != "" {
-			defs = append(defs, relation)
+		if matched == nil {
+			return "", invalidInterleaveErr
 		}
 
-		// Convert CreateIndex'es to INDEX(...) or UNIQUE(...)
-		defs = append(defs, c.getIndexes(ct, statements.CreateIndexes)...)
-
-		converted += strings.Join(defs, ",\n") + "\n);\n"
+		keyCols = append(keyCols, *matched)
 	}
 
-	return header + converted, nil
+	return d.RenderForeignKey(child, *parent, keyCols, child.Cluster.OnDelete, opts)
 }
@@ -0,0 +1,92 @@
+package spanner2mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestQuoteIdentStrict(t *testing.T) {
+	d := mysqlDialect{}
+
+	if _, err := quoteIdent(d, "group", &Options{Strict: true}); err == nil {
+		t.Error("expected an error for a reserved identifier under Strict")
+	}
+
+	long := make([]byte, d.MaxIdentLength()+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	if _, err := quoteIdent(d, string(long), &Options{Strict: true}); err == nil {
+		t.Error("expected an error for an over-length identifier under Strict")
+	}
+}
+
+func TestQuoteIdentRenameReservedWord(t *testing.T) {
+	d := mysqlDialect{}
+
+	got, err := quoteIdent(d, "group", &Options{RenameReserved: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "`group_col`"; got != want {
+		t.Errorf("quoteIdent(%q) = %q, want %q", "group", got, want)
+	}
+}
+
+func TestQuoteIdentRenameOverLengthTruncates(t *testing.T) {
+	d := mysqlDialect{}
+
+	long := make([]byte, d.MaxIdentLength()+5)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	got, err := quoteIdent(d, string(long), &Options{RenameReserved: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The quoted identifier is the backtick-wrapped, truncated name: make
+	// sure suffixing "_col" (which would only make an over-length name
+	// longer) was not applied, and the result fits MySQL's limit.
+	inner := got[1 : len(got)-1]
+	if len(inner) != d.MaxIdentLength() {
+		t.Errorf("renamed over-length identifier has length %d, want %d", len(inner), d.MaxIdentLength())
+	}
+	if inner != string(long[:d.MaxIdentLength()]) {
+		t.Errorf("quoteIdent(%q) = %q, want a plain truncation", string(long), got)
+	}
+}
+
+func TestQuoteIdentNoCollision(t *testing.T) {
+	d := mysqlDialect{}
+
+	got, err := quoteIdent(d, "user_id", &Options{Strict: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "`user_id`"; got != want {
+		t.Errorf("quoteIdent(%q) = %q, want %q", "user_id", got, want)
+	}
+}
+
+func TestGetColumnsTimestampDefaultPerDialect(t *testing.T) {
+	ct := types.CreateTableStatement{
+		TableName: "events",
+		Columns: []types.Column{
+			{Name: "created_at", Type: types.ColumnType{TypeTag: types.Timestamp}, NotNull: true},
+		},
+	}
+
+	for _, d := range []Dialect{mysqlDialect{}, postgresDialect{}} {
+		cols, err := getColumns(d, ct, &Options{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", d.Name(), err)
+		}
+		if !strings.Contains(cols[0], "DEFAULT CURRENT_TIMESTAMP") {
+			t.Errorf("%s: expected a NOT NULL TIMESTAMP column to get an implicit default, got: %q", d.Name(), cols[0])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package spanner2mysql
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/syucream/spar/src/types"
+	"gopkg.in/yaml.v3"
+)
+
+// spannerTypeNames maps a Spanner scalar type tag to the name users write in
+// a custom-types file, e.g. "STRING" or "BYTES".
+var spannerTypeNames = map[types.ScalarColumnTypeTag]string{
+	types.Bool:      "BOOL",
+	types.Int64:     "INT64",
+	types.Float64:   "FLOAT64",
+	types.String:    "STRING",
+	types.Bytes:     "BYTES",
+	types.Date:      "DATE",
+	types.Timestamp: "TIMESTAMP",
+}
+
+// CustomTypeRule overrides the target SQL type for either a specific
+// table/column pair, any column whose name matches Pattern, or every column
+// of a given Spanner scalar type. Exactly one of Table+Column, Pattern, or
+// SpannerType should be set.
+type CustomTypeRule struct {
+	Table       string `yaml:"table,omitempty"`
+	Column      string `yaml:"column,omitempty"`
+	Pattern     string `yaml:"pattern,omitempty"`
+	SpannerType string `yaml:"spanner_type,omitempty"`
+	Type        string `yaml:"type"`
+}
+
+type customTypesFile struct {
+	Types []CustomTypeRule `yaml:"types"`
+}
+
+// customTypePattern is a compiled, column-name-matching CustomTypeRule.
+type customTypePattern struct {
+	re   *regexp.Regexp
+	Type string
+}
+
+// LoadCustomTypesFile reads a --custom-types-file YAML document and splits
+// it into exact table/column and Spanner-type overrides (suitable for
+// Options.CustomTypes) and column-name patterns (Options.CustomTypePatterns).
+func LoadCustomTypesFile(path string) (map[string]string, []customTypePattern, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var f customTypesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, nil, err
+	}
+
+	exact := make(map[string]string)
+	var patterns []customTypePattern
+
+	for _, rule := range f.Types {
+		switch {
+		case rule.Table != "" && rule.Column != "":
+			exact[rule.Table+"."+rule.Column] = rule.Type
+		case rule.SpannerType != "":
+			exact[rule.SpannerType] = rule.Type
+		case rule.Pattern != "":
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, nil, fmt.Errorf("jack: invalid custom type pattern %q: %w", rule.Pattern, err)
+			}
+			patterns = append(patterns, customTypePattern{re: re, Type: rule.Type})
+		default:
+			return nil, nil, fmt.Errorf("jack: custom type rule needs table+column, spanner_type, or pattern: %+v", rule)
+		}
+	}
+
+	return exact, patterns, nil
+}
+
+// resolveColumnType applies opts.CustomTypes / opts.CustomTypePatterns before
+// falling back to the dialect's built-in type mapping.
+func resolveColumnType(d Dialect, tableName string, col types.Column, opts *Options) (string, error) {
+	if t, ok := opts.CustomTypes[tableName+"."+col.Name]; ok {
+		return t, nil
+	}
+
+	for _, p := range opts.CustomTypePatterns {
+		if p.re.MatchString(col.Name) {
+			return p.Type, nil
+		}
+	}
+
+	if name, ok := spannerTypeNames[col.Type.TypeTag]; ok {
+		if t, ok := opts.CustomTypes[name]; ok {
+			return t, nil
+		}
+	}
+
+	return d.MapType(col.Type, opts)
+}
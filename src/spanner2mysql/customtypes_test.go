@@ -0,0 +1,186 @@
+package spanner2mysql
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestResolveColumnTypeExactTableColumn(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{
+		CustomTypes: map[string]string{"users.id": "CHAR(36)"},
+	}
+	col := types.Column{Name: "id", Type: types.ColumnType{TypeTag: types.String, Length: 36}}
+
+	got, err := resolveColumnType(d, "users", col, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "CHAR(36)"; got != want {
+		t.Errorf("resolveColumnType = %q, want %q", got, want)
+	}
+}
+
+func TestResolveColumnTypePattern(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{
+		CustomTypePatterns: []customTypePattern{
+			{re: regexp.MustCompile(`_id$`), Type: "CHAR(36)"},
+		},
+	}
+	col := types.Column{Name: "user_id", Type: types.ColumnType{TypeTag: types.String, Length: 36}}
+
+	got, err := resolveColumnType(d, "orders", col, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "CHAR(36)"; got != want {
+		t.Errorf("resolveColumnType = %q, want %q", got, want)
+	}
+}
+
+func TestResolveColumnTypeSpannerTypeOverride(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{
+		CustomTypes: map[string]string{"BYTES": "BINARY(16)"},
+	}
+	col := types.Column{Name: "uuid", Type: types.ColumnType{TypeTag: types.Bytes, Length: 16}}
+
+	got, err := resolveColumnType(d, "users", col, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "BINARY(16)"; got != want {
+		t.Errorf("resolveColumnType = %q, want %q", got, want)
+	}
+}
+
+func TestResolveColumnTypeExactBeatsPatternAndSpannerType(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{
+		CustomTypes: map[string]string{
+			"users.id": "CHAR(36)",
+			"STRING":   "TEXT",
+		},
+		CustomTypePatterns: []customTypePattern{
+			{re: regexp.MustCompile(`.*`), Type: "TEXT"},
+		},
+	}
+	col := types.Column{Name: "id", Type: types.ColumnType{TypeTag: types.String, Length: 36}}
+
+	got, err := resolveColumnType(d, "users", col, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "CHAR(36)"; got != want {
+		t.Errorf("resolveColumnType = %q, want %q (table.column override should win)", got, want)
+	}
+}
+
+func TestResolveColumnTypeFallsBackToDialect(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+	col := types.Column{Name: "created_at", Type: types.ColumnType{TypeTag: types.Timestamp}}
+
+	got, err := resolveColumnType(d, "users", col, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "TIMESTAMP"; got != want {
+		t.Errorf("resolveColumnType = %q, want %q", got, want)
+	}
+}
+
+func writeCustomTypesFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "custom-types.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write custom types file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadCustomTypesFileExactAndSpannerType(t *testing.T) {
+	path := writeCustomTypesFile(t, `
+types:
+  - table: users
+    column: id
+    type: CHAR(36)
+  - spanner_type: BYTES
+    type: BINARY(16)
+`)
+
+	exact, patterns, err := LoadCustomTypesFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(patterns) != 0 {
+		t.Errorf("expected no patterns, got: %v", patterns)
+	}
+	if want := "CHAR(36)"; exact["users.id"] != want {
+		t.Errorf("exact[%q] = %q, want %q", "users.id", exact["users.id"], want)
+	}
+	if want := "BINARY(16)"; exact["BYTES"] != want {
+		t.Errorf("exact[%q] = %q, want %q", "BYTES", exact["BYTES"], want)
+	}
+}
+
+func TestLoadCustomTypesFilePattern(t *testing.T) {
+	path := writeCustomTypesFile(t, `
+types:
+  - pattern: "_id$"
+    type: CHAR(36)
+`)
+
+	exact, patterns, err := LoadCustomTypesFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exact) != 0 {
+		t.Errorf("expected no exact overrides, got: %v", exact)
+	}
+	if len(patterns) != 1 {
+		t.Fatalf("expected 1 pattern, got %d: %v", len(patterns), patterns)
+	}
+	if want := "CHAR(36)"; patterns[0].Type != want {
+		t.Errorf("patterns[0].Type = %q, want %q", patterns[0].Type, want)
+	}
+	if !patterns[0].re.MatchString("user_id") {
+		t.Errorf("compiled pattern should match %q", "user_id")
+	}
+}
+
+func TestLoadCustomTypesFileInvalidPattern(t *testing.T) {
+	path := writeCustomTypesFile(t, `
+types:
+  - pattern: "("
+    type: CHAR(36)
+`)
+
+	if _, _, err := LoadCustomTypesFile(path); err == nil {
+		t.Error("expected an error for an invalid regexp pattern")
+	}
+}
+
+func TestLoadCustomTypesFileRuleWithNoSelector(t *testing.T) {
+	path := writeCustomTypesFile(t, `
+types:
+  - type: CHAR(36)
+`)
+
+	if _, _, err := LoadCustomTypesFile(path); err == nil {
+		t.Error("expected an error for a rule with no table+column, spanner_type, or pattern")
+	}
+}
+
+func TestLoadCustomTypesFileMissingFile(t *testing.T) {
+	if _, _, err := LoadCustomTypesFile(filepath.Join(t.TempDir(), "does-not-exist.yaml")); err == nil {
+		t.Error("expected an error for a missing custom types file")
+	}
+}
@@ -0,0 +1,65 @@
+package spanner2mysql
+
+import (
+	"fmt"
+
+	"github.com/syucream/spar/src/types"
+)
+
+// Dialect abstracts the target SQL flavor so that Convert can transpile
+// Spanner DDL into more than just MySQL. Implementations register themselves
+// with RegisterDialect, typically from an init function.
+type Dialect interface {
+	// Name returns the name the dialect is registered under, e.g. "mysql".
+	Name() string
+	// Header returns the boilerplate comment prefixed to generated output.
+	Header() string
+	// QuoteIdent quotes a table/column/index identifier for this dialect.
+	QuoteIdent(name string) string
+	// IsReserved reports whether name collides with one of this dialect's
+	// reserved keywords.
+	IsReserved(name string) bool
+	// MaxIdentLength returns the longest identifier this dialect accepts.
+	MaxIdentLength() int
+	// MapType converts a Spanner column type into this dialect's type syntax.
+	MapType(t types.ColumnType, opts *Options) (string, error)
+	// RenderPrimaryKey renders the PRIMARY KEY clause for ct, or returns
+	// invalidKeyErr if ct has no usable primary key.
+	RenderPrimaryKey(ct types.CreateTableStatement, opts *Options) (string, error)
+	// RenderForeignKey renders the FOREIGN KEY clause linking child to parent
+	// via keyCols (the full, ordered parent primary key), applying onDelete
+	// from the Spanner INTERLEAVE clause. Returns invalidKeyErr if keyCols
+	// can't be used as a key.
+	RenderForeignKey(child, parent types.CreateTableStatement, keyCols []types.Column, onDelete types.OnDelete, opts *Options) (string, error)
+	// RenderIndex renders the INDEX/UNIQUE clauses declared on table. defs are
+	// appended as CREATE TABLE-level constraints; trailing are full
+	// statements appended after the CREATE TABLE (needed by dialects such as
+	// Postgres whose CREATE TABLE grammar has no plain-index constraint).
+	RenderIndex(table types.CreateTableStatement, indexes []types.CreateIndexStatement, opts *Options) (defs []string, trailing []string, err error)
+	// ArrayCheckConstraint returns an extra column-level constraint clause to
+	// append after an ARRAY<T> column's type (ident is that column's already
+	// quoted identifier), or "" if none is needed. Dialects with a native
+	// array type (e.g. Postgres) need none. Dialects that fall back to JSON
+	// storage should validate the column actually holds an array of t's
+	// element type under opts.Strict, and error under Strict if they have no
+	// way to express that validation for t.
+	ArrayCheckConstraint(t types.ColumnType, ident string, opts *Options) (string, error)
+}
+
+var dialects = map[string]Dialect{}
+
+// RegisterDialect makes a Dialect available to Convert under name. Dialects
+// are expected to call this from an init function, mirroring the pattern
+// database/sql uses for drivers.
+func RegisterDialect(name string, d Dialect) {
+	dialects[name] = d
+}
+
+func lookupDialect(name string) (Dialect, error) {
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("jack: unknown dialect %q", name)
+	}
+
+	return d, nil
+}
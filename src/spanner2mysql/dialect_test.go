@@ -0,0 +1,86 @@
+package spanner2mysql
+
+import (
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestLookupDialect(t *testing.T) {
+	for _, name := range []string{"mysql", "postgres"} {
+		if _, err := lookupDialect(name); err != nil {
+			t.Errorf("lookupDialect(%q) returned an error: %v", name, err)
+		}
+	}
+
+	if _, err := lookupDialect("oracle"); err == nil {
+		t.Error("lookupDialect(\"oracle\") should fail for an unregistered dialect")
+	}
+}
+
+func TestMysqlDialectMapType(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	cases := []struct {
+		tag  types.ScalarColumnTypeTag
+		want string
+	}{
+		{types.Bool, "TINYINT(1)"},
+		{types.Int64, "BIGINT"},
+		{types.Float64, "DOUBLE"},
+		{types.String, "VARCHAR"},
+		{types.Bytes, "BLOB"},
+		{types.Date, "DATE"},
+		{types.Timestamp, "TIMESTAMP"},
+	}
+
+	for _, c := range cases {
+		got, err := d.MapType(types.ColumnType{TypeTag: c.tag}, opts)
+		if err != nil {
+			t.Errorf("MapType(%v) returned an error: %v", c.tag, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("MapType(%v) = %q, want %q", c.tag, got, c.want)
+		}
+	}
+}
+
+func TestPostgresDialectMapType(t *testing.T) {
+	d := postgresDialect{}
+	opts := &Options{}
+
+	cases := []struct {
+		tag  types.ScalarColumnTypeTag
+		want string
+	}{
+		{types.Bool, "BOOLEAN"},
+		{types.Int64, "BIGINT"},
+		{types.Float64, "DOUBLE PRECISION"},
+		{types.String, "VARCHAR"},
+		{types.Bytes, "BYTEA"},
+		{types.Date, "DATE"},
+		{types.Timestamp, "TIMESTAMPTZ"},
+	}
+
+	for _, c := range cases {
+		got, err := d.MapType(types.ColumnType{TypeTag: c.tag}, opts)
+		if err != nil {
+			t.Errorf("MapType(%v) returned an error: %v", c.tag, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("MapType(%v) = %q, want %q", c.tag, got, c.want)
+		}
+	}
+}
+
+func TestQuoteIdentPerDialect(t *testing.T) {
+	if got, want := (mysqlDialect{}).QuoteIdent("users"), "`users`"; got != want {
+		t.Errorf("mysqlDialect.QuoteIdent = %q, want %q", got, want)
+	}
+	if got, want := (postgresDialect{}).QuoteIdent("users"), `"users"`; got != want {
+		t.Errorf("postgresDialect.QuoteIdent = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package spanner2mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestMysqlRenderIndex(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	table := types.CreateTableStatement{TableName: "users"}
+	indexes := []types.CreateIndexStatement{
+		{TableName: "users", IndexName: "idx_email", Keys: []types.Key{{Name: "email"}}, Unique: false},
+		{TableName: "users", IndexName: "uq_handle", Keys: []types.Key{{Name: "handle"}}, Unique: true},
+	}
+
+	defs, trailing, err := d.RenderIndex(table, indexes, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trailing) != 0 {
+		t.Errorf("mysql should render indexes inline, got trailing statements: %v", trailing)
+	}
+	if len(defs) != 2 {
+		t.Fatalf("expected 2 index defs, got %d: %v", len(defs), defs)
+	}
+	if !strings.Contains(defs[0], "INDEX `idx_email` (`email`)") {
+		t.Errorf("non-unique index rendered wrong: %q", defs[0])
+	}
+	if !strings.Contains(defs[1], "UNIQUE (`handle`)") {
+		t.Errorf("unique index rendered wrong: %q", defs[1])
+	}
+}
+
+func TestPostgresRenderIndex(t *testing.T) {
+	d := postgresDialect{}
+	opts := &Options{}
+
+	table := types.CreateTableStatement{TableName: "users"}
+	indexes := []types.CreateIndexStatement{
+		{TableName: "users", IndexName: "idx_email", Keys: []types.Key{{Name: "email"}}, Unique: false},
+		{TableName: "users", IndexName: "uq_handle", Keys: []types.Key{{Name: "handle"}}, Unique: true},
+	}
+
+	defs, trailing, err := d.RenderIndex(table, indexes, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A non-unique index has no CREATE TABLE-level equivalent in Postgres, so
+	// it must come back as a standalone CREATE INDEX statement, not a def.
+	if len(defs) != 1 || !strings.Contains(defs[0], `UNIQUE ("handle")`) {
+		t.Errorf("expected exactly one UNIQUE def, got: %v", defs)
+	}
+	if len(trailing) != 1 {
+		t.Fatalf("expected exactly one trailing CREATE INDEX statement, got %d: %v", len(trailing), trailing)
+	}
+	if want := `CREATE INDEX "idx_email" ON "users" ("email");`; trailing[0] != want {
+		t.Errorf("trailing statement = %q, want %q", trailing[0], want)
+	}
+}
@@ -0,0 +1,237 @@
+package spanner2mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/syucream/spar/src/types"
+)
+
+const mysqlHeader = "-- Auto-generated by jackup. DO NOT EDIT!\n--\n\n"
+
+var toMysqlType = map[types.ScalarColumnTypeTag]string{
+	types.Bool:      "TINYINT(1)",
+	types.Int64:     "BIGINT",
+	types.Float64:   "DOUBLE",
+	types.String:    "VARCHAR",
+	types.Bytes:     "BLOB",
+	types.Date:      "DATE",
+	types.Timestamp: "TIMESTAMP",
+}
+
+// mysqlDialect is the original, MySQL-specific target dialect.
+type mysqlDialect struct{}
+
+func init() {
+	RegisterDialect("mysql", mysqlDialect{})
+}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) Header() string { return mysqlHeader }
+
+func (mysqlDialect) QuoteIdent(name string) string {
+	return fmt.Sprintf("`%s`", name)
+}
+
+func (mysqlDialect) IsReserved(name string) bool {
+	return isReserved(mysqlReservedWords, name)
+}
+
+// MaxIdentLength is MySQL's limit for table, column, and index names.
+func (mysqlDialect) MaxIdentLength() int { return 64 }
+
+// mysqlArrayCastType picks the CAST(...AS <type> ARRAY) target for a
+// multi-valued index on a Spanner ARRAY<elem> column, matching elem rather
+// than always assuming an unsigned integer.
+func mysqlArrayCastType(elem types.ColumnType) string {
+	switch elem.TypeTag {
+	case types.Bool:
+		return "UNSIGNED"
+	case types.Int64:
+		return "SIGNED"
+	case types.Date:
+		return "DATE"
+	case types.Timestamp:
+		return "DATETIME"
+	case types.Float64:
+		return "DECIMAL(65, 30)"
+	case types.Bytes:
+		n := elem.Length
+		if n <= 0 || n > 255 {
+			n = 255
+		}
+		return fmt.Sprintf("BINARY(%d)", n)
+	case types.String:
+		fallthrough
+	default:
+		n := elem.Length
+		if n <= 0 || n > 255 {
+			n = 255
+		}
+		return fmt.Sprintf("CHAR(%d)", n)
+	}
+}
+
+// mysqlJSONSchemaType maps a Spanner scalar type tag to the JSON Schema
+// "type" its JSON-encoded form satisfies, for JSON_SCHEMA_VALID validation
+// of ARRAY<T> columns. BYTES/DATE/TIMESTAMP are all encoded as JSON strings.
+var mysqlJSONSchemaType = map[types.ScalarColumnTypeTag]string{
+	types.Bool:      "boolean",
+	types.Int64:     "integer",
+	types.Float64:   "number",
+	types.String:    "string",
+	types.Bytes:     "string",
+	types.Date:      "string",
+	types.Timestamp: "string",
+}
+
+func (d mysqlDialect) MapType(t types.ColumnType, opts *Options) (string, error) {
+	// MySQL has no native array type; Spanner ARRAY<T> columns are stored as
+	// JSON, with RenderIndex emitting a multi-valued index for indexed ones.
+	if t.IsArray {
+		return "JSON", nil
+	}
+
+	convertedType := ""
+
+	if v, ok := toMysqlType[t.TypeTag]; ok {
+		convertedType = v
+		// Replace too big VARCHAR to TEXT or append length attribute for VARCHAR
+		if opts.AllowConvertString && t.TypeTag == types.String {
+			if t.Length > 256 {
+				convertedType = "TEXT"
+			} else {
+				convertedType += fmt.Sprintf("(%d)", t.Length)
+			}
+		}
+	} else {
+		return "", invalidSpannerErr
+	}
+
+	return convertedType, nil
+}
+
+func (d mysqlDialect) RenderPrimaryKey(ct types.CreateTableStatement, opts *Options) (string, error) {
+	expectedLen := len(ct.PrimaryKeys)
+	keyNames := make([]string, 0, expectedLen)
+
+	for _, pk := range ct.PrimaryKeys {
+		for _, col := range ct.Columns {
+			if col.Name == pk.Name {
+				// Check precondition
+				if !col.NotNull {
+					return "", invalidKeyErr
+				}
+
+				kn, err := quoteIdent(d, pk.Name, opts)
+				if err != nil {
+					return "", err
+				}
+
+				if mt, err := d.MapType(col.Type, opts); err == nil && (mt == "TEXT" || mt == "BLOB") {
+					kn += fmt.Sprintf("(%d)", pseudoKeyLength)
+				}
+
+				keyNames = append(keyNames, kn)
+			}
+		}
+	}
+
+	if expectedLen == len(keyNames) {
+		return fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(keyNames, ", ")), nil
+	}
+
+	return "", invalidKeyErr
+}
+
+func (d mysqlDialect) RenderForeignKey(child, parent types.CreateTableStatement, keyCols []types.Column, onDelete types.OnDelete, opts *Options) (string, error) {
+	childIdents := make([]string, 0, len(keyCols))
+
+	for _, kc := range keyCols {
+		// FOREIGN KEY TO TEXT or BLOB isn't supported
+		if mt, err := d.MapType(kc.Type, opts); err != nil {
+			return "", err
+		} else if mt == "TEXT" || mt == "BLOB" {
+			return "", invalidKeyErr
+		}
+
+		ident, err := quoteIdent(d, kc.Name, opts)
+		if err != nil {
+			return "", err
+		}
+
+		childIdents = append(childIdents, ident)
+	}
+
+	parentIdent, err := quoteIdent(d, parent.TableName, opts)
+	if err != nil {
+		return "", err
+	}
+
+	action := "RESTRICT"
+	if onDelete == types.Cascade {
+		action = "CASCADE"
+	}
+
+	return fmt.Sprintf("  FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s",
+		strings.Join(childIdents, ", "), parentIdent, strings.Join(childIdents, ", "), action), nil
+}
+
+func (d mysqlDialect) RenderIndex(table types.CreateTableStatement, indexes []types.CreateIndexStatement, opts *Options) ([]string, []string, error) {
+	var strIndexes []string
+
+	for _, i := range indexes {
+		if table.TableName == i.TableName {
+			keys := make([]string, 0, len(i.Keys))
+			for _, k := range i.Keys {
+				ident, err := quoteIdent(d, k.Name, opts)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if col, ok := lookupColumn(table, k.Name); ok && col.Type.IsArray {
+					ident = fmt.Sprintf("(CAST(%s->'$' AS %s ARRAY))", ident, mysqlArrayCastType(col.Type))
+				}
+
+				keys = append(keys, ident)
+			}
+
+			if i.Unique {
+				strIndexes = append(strIndexes, fmt.Sprintf("  UNIQUE (%s)", strings.Join(keys, ", ")))
+			} else {
+				iname := i.IndexName
+				if opts.AllowShotenIndexName && len(iname) > 255 {
+					iname = ""
+				}
+
+				indexIdent, err := quoteIdent(d, iname, opts)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				strIndexes = append(strIndexes, fmt.Sprintf("  INDEX %s (%s)", indexIdent, strings.Join(keys, ", ")))
+			}
+		}
+	}
+
+	return strIndexes, nil, nil
+}
+
+// ArrayCheckConstraint validates that a JSON column actually holds an array
+// of t's element type, using MySQL 8.0's JSON_SCHEMA_VALID. Under
+// opts.Strict == false the column is left as plain, unconstrained JSON.
+func (d mysqlDialect) ArrayCheckConstraint(t types.ColumnType, ident string, opts *Options) (string, error) {
+	if !opts.Strict {
+		return "", nil
+	}
+
+	itemType, ok := mysqlJSONSchemaType[t.TypeTag]
+	if !ok {
+		return "", fmt.Errorf("jack: mysql dialect has no JSON array validation for Spanner type %v under Strict", t.TypeTag)
+	}
+
+	schema := fmt.Sprintf(`{"type": "array", "items": {"type": "%s"}}`, itemType)
+
+	return fmt.Sprintf("CHECK (JSON_SCHEMA_VALID('%s', %s))", schema, ident), nil
+}
@@ -0,0 +1,142 @@
+package spanner2mysql
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/syucream/spar/src/types"
+)
+
+// NamingConvention controls how Convert rewrites table, column, and index
+// identifiers before rendering them.
+type NamingConvention int
+
+const (
+	// AsIs leaves identifiers untouched.
+	AsIs NamingConvention = iota
+	// SnakeCase rewrites identifiers to snake_case (UserID -> user_id).
+	SnakeCase
+	// LowerCamel rewrites identifiers to lowerCamelCase (user_id -> userId).
+	LowerCamel
+)
+
+// renameIdent rewrites name according to nc. It treats a run of uppercase
+// letters as a single acronym (UserID -> user_id, not user_i_d).
+func renameIdent(name string, nc NamingConvention) string {
+	switch nc {
+	case SnakeCase:
+		return toSnakeCase(name)
+	case LowerCamel:
+		return toLowerCamel(name)
+	default:
+		return name
+	}
+}
+
+func splitWords(name string) []string {
+	var words []string
+	var cur []rune
+
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+		case unicode.IsUpper(r):
+			// Start a new word on a lower->upper transition, or on the last
+			// letter of an acronym run followed by a new word
+			// (UserID -> User, ID; IDCard -> ID, Card).
+			if len(cur) > 0 {
+				prevUpper := unicode.IsUpper(runes[i-1])
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if !prevUpper || nextLower {
+					flush()
+				}
+			}
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+
+	return words
+}
+
+func toSnakeCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	return strings.Join(words, "_")
+}
+
+func toLowerCamel(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		words[i] = w
+	}
+
+	return strings.Join(words, "")
+}
+
+// applyNamingConvention returns a copy of statements with every table,
+// column, primary-key, index, and interleave identifier rewritten according
+// to nc. Rewriting up front keeps getColumns/getRelation/RenderIndex free of
+// naming concerns and guarantees interleave and index lookups stay
+// consistent with the renamed tables and columns.
+func applyNamingConvention(statements *types.DDStatements, nc NamingConvention) *types.DDStatements {
+	if nc == AsIs {
+		return statements
+	}
+
+	tables := make([]types.CreateTableStatement, len(statements.CreateTables))
+	copy(tables, statements.CreateTables)
+
+	for i := range tables {
+		ct := &tables[i]
+		ct.TableName = renameIdent(ct.TableName, nc)
+
+		ct.Columns = append([]types.Column(nil), ct.Columns...)
+		for j := range ct.Columns {
+			ct.Columns[j].Name = renameIdent(ct.Columns[j].Name, nc)
+		}
+
+		ct.PrimaryKeys = append([]types.Key(nil), ct.PrimaryKeys...)
+		for j := range ct.PrimaryKeys {
+			ct.PrimaryKeys[j].Name = renameIdent(ct.PrimaryKeys[j].Name, nc)
+		}
+
+		if ct.Cluster.TableName != "" {
+			ct.Cluster.TableName = renameIdent(ct.Cluster.TableName, nc)
+		}
+	}
+
+	indexes := make([]types.CreateIndexStatement, len(statements.CreateIndexes))
+	copy(indexes, statements.CreateIndexes)
+
+	for i := range indexes {
+		ci := &indexes[i]
+		ci.TableName = renameIdent(ci.TableName, nc)
+		ci.IndexName = renameIdent(ci.IndexName, nc)
+
+		ci.Keys = append([]types.Key(nil), ci.Keys...)
+		for j := range ci.Keys {
+			ci.Keys[j].Name = renameIdent(ci.Keys[j].Name, nc)
+		}
+	}
+
+	return &types.DDStatements{CreateTables: tables, CreateIndexes: indexes}
+}
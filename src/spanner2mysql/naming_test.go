@@ -0,0 +1,119 @@
+package spanner2mysql
+
+import (
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"UserID":       "user_id",
+		"IDCard":       "id_card",
+		"UserName":     "user_name",
+		"user_name":    "user_name",
+		"HTTPRequest":  "http_request",
+		"alreadyLower": "already_lower",
+	}
+
+	for in, want := range cases {
+		if got := toSnakeCase(in); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestToLowerCamel(t *testing.T) {
+	cases := map[string]string{
+		"user_id":   "userId",
+		"user_name": "userName",
+		"id":        "id",
+		"UserID":    "userId",
+	}
+
+	for in, want := range cases {
+		if got := toLowerCamel(in); got != want {
+			t.Errorf("toLowerCamel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestApplyNamingConventionRoundTrip builds a small interleaved schema with
+// acronym-heavy identifiers and verifies that after SnakeCase rewriting,
+// every identifier produced for MySQL is valid: no longer than 64 chars and
+// not colliding with a reserved word.
+func TestApplyNamingConventionRoundTrip(t *testing.T) {
+	statements := &types.DDStatements{
+		CreateTables: []types.CreateTableStatement{
+			{
+				TableName: "UserAccount",
+				Columns: []types.Column{
+					{Name: "UserID", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+					{Name: "GroupName", Type: types.ColumnType{TypeTag: types.String, Length: 64}, NotNull: true},
+				},
+				PrimaryKeys: []types.Key{
+					{Name: "UserID"},
+				},
+			},
+			{
+				TableName: "UserOrder",
+				Columns: []types.Column{
+					{Name: "UserID", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+					{Name: "OrderID", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+				},
+				PrimaryKeys: []types.Key{
+					{Name: "UserID"},
+					{Name: "OrderID"},
+				},
+				Cluster: types.Cluster{TableName: "UserAccount", OnDelete: types.Cascade},
+			},
+		},
+		CreateIndexes: []types.CreateIndexStatement{
+			{
+				TableName: "UserAccount",
+				IndexName: "UserAccountByGroupName",
+				Keys:      []types.Key{{Name: "GroupName"}},
+			},
+		},
+	}
+
+	renamed := applyNamingConvention(statements, SnakeCase)
+
+	child := renamed.CreateTables[1]
+	if child.Cluster.TableName != "user_account" {
+		t.Fatalf("interleave parent reference not renamed: got %q", child.Cluster.TableName)
+	}
+
+	parentNames := map[string]bool{}
+	for _, ct := range renamed.CreateTables {
+		parentNames[ct.TableName] = true
+		checkValidMysqlIdent(t, ct.TableName)
+		for _, col := range ct.Columns {
+			checkValidMysqlIdent(t, col.Name)
+		}
+	}
+	if !parentNames[child.Cluster.TableName] {
+		t.Fatalf("renamed interleave parent %q has no matching CreateTableStatement", child.Cluster.TableName)
+	}
+
+	for _, ci := range renamed.CreateIndexes {
+		checkValidMysqlIdent(t, ci.IndexName)
+		for _, k := range ci.Keys {
+			checkValidMysqlIdent(t, k.Name)
+		}
+	}
+}
+
+func checkValidMysqlIdent(t *testing.T, name string) {
+	t.Helper()
+
+	d := mysqlDialect{}
+
+	if len(name) > d.MaxIdentLength() {
+		t.Errorf("identifier %q exceeds MySQL's %d char limit", name, d.MaxIdentLength())
+	}
+
+	if d.IsReserved(name) {
+		t.Errorf("identifier %q collides with a MySQL reserved word after renaming", name)
+	}
+}
@@ -0,0 +1,182 @@
+package spanner2mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/syucream/spar/src/types"
+)
+
+const postgresHeader = "-- Auto-generated by jackup. DO NOT EDIT!\n--\n\n"
+
+var toPostgresType = map[types.ScalarColumnTypeTag]string{
+	types.Bool:      "BOOLEAN",
+	types.Int64:     "BIGINT",
+	types.Float64:   "DOUBLE PRECISION",
+	types.String:    "VARCHAR",
+	types.Bytes:     "BYTEA",
+	types.Date:      "DATE",
+	types.Timestamp: "TIMESTAMPTZ",
+}
+
+// postgresDialect targets PostgreSQL. Unlike MySQL it has no fixed-length
+// index limit, so primary/foreign keys on TEXT/BYTEA columns need no pseudo
+// key length, and identifiers are quoted with double quotes instead of
+// backticks.
+type postgresDialect struct{}
+
+func init() {
+	RegisterDialect("postgres", postgresDialect{})
+}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) Header() string { return postgresHeader }
+
+func (postgresDialect) QuoteIdent(name string) string {
+	return fmt.Sprintf("%q", name)
+}
+
+func (postgresDialect) IsReserved(name string) bool {
+	return isReserved(postgresReservedWords, name)
+}
+
+// MaxIdentLength is PostgreSQL's NAMEDATALEN-derived identifier limit.
+func (postgresDialect) MaxIdentLength() int { return 63 }
+
+func (d postgresDialect) MapType(t types.ColumnType, opts *Options) (string, error) {
+	if t.IsArray {
+		// Postgres has native array types, so ARRAY<T> maps directly to T[]
+		// instead of the JSON workaround MySQL needs.
+		elem, ok := toPostgresType[t.TypeTag]
+		if !ok {
+			return "", invalidSpannerErr
+		}
+
+		return elem + "[]", nil
+	}
+
+	convertedType := ""
+
+	if v, ok := toPostgresType[t.TypeTag]; ok {
+		convertedType = v
+		if opts.AllowConvertString && t.TypeTag == types.String {
+			if t.Length > 256 {
+				convertedType = "TEXT"
+			} else {
+				convertedType += fmt.Sprintf("(%d)", t.Length)
+			}
+		}
+	} else {
+		return "", invalidSpannerErr
+	}
+
+	return convertedType, nil
+}
+
+func (d postgresDialect) RenderPrimaryKey(ct types.CreateTableStatement, opts *Options) (string, error) {
+	expectedLen := len(ct.PrimaryKeys)
+	keyNames := make([]string, 0, expectedLen)
+
+	for _, pk := range ct.PrimaryKeys {
+		for _, col := range ct.Columns {
+			if col.Name == pk.Name {
+				// Check precondition
+				if !col.NotNull {
+					return "", invalidKeyErr
+				}
+
+				// No pseudo key length trick needed: Postgres indexes TEXT
+				// and BYTEA columns without a declared length.
+				ident, err := quoteIdent(d, pk.Name, opts)
+				if err != nil {
+					return "", err
+				}
+
+				keyNames = append(keyNames, ident)
+			}
+		}
+	}
+
+	if expectedLen == len(keyNames) {
+		return fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(keyNames, ", ")), nil
+	}
+
+	return "", invalidKeyErr
+}
+
+func (d postgresDialect) RenderForeignKey(child, parent types.CreateTableStatement, keyCols []types.Column, onDelete types.OnDelete, opts *Options) (string, error) {
+	childIdents := make([]string, 0, len(keyCols))
+
+	for _, kc := range keyCols {
+		ident, err := quoteIdent(d, kc.Name, opts)
+		if err != nil {
+			return "", err
+		}
+
+		childIdents = append(childIdents, ident)
+	}
+
+	parentIdent, err := quoteIdent(d, parent.TableName, opts)
+	if err != nil {
+		return "", err
+	}
+
+	action := "RESTRICT"
+	if onDelete == types.Cascade {
+		action = "CASCADE"
+	}
+
+	return fmt.Sprintf("  FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s",
+		strings.Join(childIdents, ", "), parentIdent, strings.Join(childIdents, ", "), action), nil
+}
+
+// RenderIndex renders unique indexes as CREATE TABLE-level UNIQUE constraints,
+// since Postgres supports that directly. Non-unique indexes have no
+// equivalent table-level constraint in Postgres's CREATE TABLE grammar, so
+// those are rendered as standalone CREATE INDEX statements appended after
+// the CREATE TABLE instead.
+func (d postgresDialect) RenderIndex(table types.CreateTableStatement, indexes []types.CreateIndexStatement, opts *Options) ([]string, []string, error) {
+	var defs []string
+	var trailing []string
+
+	for _, i := range indexes {
+		if table.TableName != i.TableName {
+			continue
+		}
+
+		keys := make([]string, 0, len(i.Keys))
+		for _, k := range i.Keys {
+			ident, err := quoteIdent(d, k.Name, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			keys = append(keys, ident)
+		}
+
+		if i.Unique {
+			defs = append(defs, fmt.Sprintf("  UNIQUE (%s)", strings.Join(keys, ", ")))
+			continue
+		}
+
+		indexIdent, err := quoteIdent(d, i.IndexName, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tableIdent, err := quoteIdent(d, table.TableName, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		trailing = append(trailing, fmt.Sprintf("CREATE INDEX %s ON %s (%s);", indexIdent, tableIdent, strings.Join(keys, ", ")))
+	}
+
+	return defs, trailing, nil
+}
+
+// ArrayCheckConstraint is a no-op: Postgres has a native array type, so
+// ARRAY<T> columns need no JSON schema validation.
+func (postgresDialect) ArrayCheckConstraint(t types.ColumnType, ident string, opts *Options) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,229 @@
+package spanner2mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/syucream/spar/src/types"
+)
+
+func TestMysqlRenderForeignKeyAcceptsValidKey(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	child := types.CreateTableStatement{TableName: "orders"}
+	parent := types.CreateTableStatement{TableName: "users"}
+	keyCols := []types.Column{
+		{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+	}
+
+	// Before the "||"-should-be-"&&" fix, a successfully mapped (err == nil)
+	// key column was always rejected as invalidKeyErr, regardless of type.
+	got, err := d.RenderForeignKey(child, parent, keyCols, types.NoAction, opts)
+	if err != nil {
+		t.Fatalf("RenderForeignKey rejected a valid INT64 key: %v", err)
+	}
+	if !strings.Contains(got, "FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`)") {
+		t.Errorf("unexpected FOREIGN KEY clause: %q", got)
+	}
+	if !strings.HasSuffix(got, "ON DELETE RESTRICT") {
+		t.Errorf("expected default ON DELETE RESTRICT, got: %q", got)
+	}
+}
+
+func TestMysqlRenderForeignKeyRejectsTextBlobKey(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	child := types.CreateTableStatement{TableName: "orders"}
+	parent := types.CreateTableStatement{TableName: "users"}
+	keyCols := []types.Column{
+		{Name: "bio", Type: types.ColumnType{TypeTag: types.Bytes}, NotNull: true},
+	}
+
+	if _, err := d.RenderForeignKey(child, parent, keyCols, types.NoAction, opts); err != invalidKeyErr {
+		t.Errorf("RenderForeignKey(BYTES key) = %v, want invalidKeyErr", err)
+	}
+}
+
+func TestMysqlRenderForeignKeyOnDeleteCascade(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	child := types.CreateTableStatement{TableName: "orders"}
+	parent := types.CreateTableStatement{TableName: "users"}
+	keyCols := []types.Column{
+		{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+	}
+
+	got, err := d.RenderForeignKey(child, parent, keyCols, types.Cascade, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(got, "ON DELETE CASCADE") {
+		t.Errorf("expected ON DELETE CASCADE, got: %q", got)
+	}
+}
+
+func TestGetRelationMatchesFullCompositeParentKey(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	parent := types.CreateTableStatement{
+		TableName: "users",
+		Columns: []types.Column{
+			{Name: "tenant_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+			{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+		},
+		PrimaryKeys: []types.Key{
+			{Name: "tenant_id"},
+			{Name: "user_id"},
+		},
+	}
+	child := types.CreateTableStatement{
+		TableName: "orders",
+		Columns: []types.Column{
+			{Name: "tenant_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+			{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+			{Name: "order_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+		},
+		Cluster: types.Cluster{TableName: "users", OnDelete: types.Cascade},
+	}
+
+	got, err := getRelation(d, child, []types.CreateTableStatement{parent, child}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(got, "FOREIGN KEY (`tenant_id`, `user_id`) REFERENCES `users` (`tenant_id`, `user_id`)") {
+		t.Errorf("composite interleave key not fully matched: %q", got)
+	}
+	if !strings.HasSuffix(got, "ON DELETE CASCADE") {
+		t.Errorf("expected ON DELETE CASCADE, got: %q", got)
+	}
+}
+
+func TestGetRelationRejectsTypeMismatchedKeyColumn(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	parent := types.CreateTableStatement{
+		TableName: "users",
+		Columns: []types.Column{
+			{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+		},
+		PrimaryKeys: []types.Key{{Name: "user_id"}},
+	}
+	child := types.CreateTableStatement{
+		TableName: "orders",
+		// A same-named column with a different type must not be accepted as
+		// the interleave key: matching by name alone would produce a FOREIGN
+		// KEY referencing a column of the wrong type.
+		Columns: []types.Column{
+			{Name: "user_id", Type: types.ColumnType{TypeTag: types.String, Length: 36}, NotNull: true},
+		},
+		Cluster: types.Cluster{TableName: "users", OnDelete: types.Cascade},
+	}
+
+	if _, err := getRelation(d, child, []types.CreateTableStatement{parent, child}, opts); err != invalidInterleaveErr {
+		t.Errorf("getRelation(type-mismatched key) = %v, want invalidInterleaveErr", err)
+	}
+}
+
+func TestGetRelationNoInterleave(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	child := types.CreateTableStatement{TableName: "users"}
+
+	got, err := getRelation(d, child, nil, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected no relation clause, got: %q", got)
+	}
+}
+
+func TestMysqlRenderPrimaryKey(t *testing.T) {
+	d := mysqlDialect{}
+	opts := &Options{}
+
+	ct := types.CreateTableStatement{
+		TableName: "users",
+		Columns: []types.Column{
+			{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+		},
+		PrimaryKeys: []types.Key{{Name: "user_id"}},
+	}
+
+	got, err := d.RenderPrimaryKey(ct, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "  PRIMARY KEY (`user_id`)" {
+		t.Errorf("unexpected PRIMARY KEY clause: %q", got)
+	}
+}
+
+func TestPostgresRenderPrimaryKey(t *testing.T) {
+	d := postgresDialect{}
+	opts := &Options{}
+
+	ct := types.CreateTableStatement{
+		TableName: "users",
+		Columns: []types.Column{
+			{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+		},
+		PrimaryKeys: []types.Key{{Name: "user_id"}},
+	}
+
+	got, err := d.RenderPrimaryKey(ct, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `  PRIMARY KEY ("user_id")` {
+		t.Errorf("unexpected PRIMARY KEY clause: %q", got)
+	}
+}
+
+func TestPostgresRenderForeignKeyAcceptsValidKey(t *testing.T) {
+	d := postgresDialect{}
+	opts := &Options{}
+
+	child := types.CreateTableStatement{TableName: "orders"}
+	parent := types.CreateTableStatement{TableName: "users"}
+	keyCols := []types.Column{
+		{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+	}
+
+	got, err := d.RenderForeignKey(child, parent, keyCols, types.NoAction, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, `FOREIGN KEY ("user_id") REFERENCES "users" ("user_id")`) {
+		t.Errorf("unexpected FOREIGN KEY clause: %q", got)
+	}
+	if !strings.HasSuffix(got, "ON DELETE RESTRICT") {
+		t.Errorf("expected default ON DELETE RESTRICT, got: %q", got)
+	}
+}
+
+func TestPostgresRenderForeignKeyOnDeleteCascade(t *testing.T) {
+	d := postgresDialect{}
+	opts := &Options{}
+
+	child := types.CreateTableStatement{TableName: "orders"}
+	parent := types.CreateTableStatement{TableName: "users"}
+	keyCols := []types.Column{
+		{Name: "user_id", Type: types.ColumnType{TypeTag: types.Int64}, NotNull: true},
+	}
+
+	got, err := d.RenderForeignKey(child, parent, keyCols, types.Cascade, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(got, "ON DELETE CASCADE") {
+		t.Errorf("expected ON DELETE CASCADE, got: %q", got)
+	}
+}
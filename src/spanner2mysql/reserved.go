@@ -0,0 +1,58 @@
+package spanner2mysql
+
+import "strings"
+
+// mysqlReservedWords lists MySQL reserved keywords that Spanner nonetheless
+// allows as identifiers (https://dev.mysql.com/doc/refman/8.0/en/keywords.html).
+// This isn't exhaustive, just the ones that come up in practice.
+var mysqlReservedWords = map[string]bool{
+	"READ":       true,
+	"KEY":        true,
+	"KEYS":       true,
+	"GROUP":      true,
+	"GROUPS":     true,
+	"RANK":       true,
+	"ORDER":      true,
+	"TABLE":      true,
+	"SELECT":     true,
+	"WHERE":      true,
+	"INDEX":      true,
+	"PRIMARY":    true,
+	"FOREIGN":    true,
+	"CONSTRAINT": true,
+	"DEFAULT":    true,
+	"VALUES":     true,
+	"LIMIT":      true,
+	"OFFSET":     true,
+	"RANGE":      true,
+	"ROW":        true,
+	"ROWS":       true,
+}
+
+// postgresReservedWords lists PostgreSQL reserved keywords that Spanner
+// nonetheless allows as identifiers
+// (https://www.postgresql.org/docs/current/sql-keywords-appendix.html).
+var postgresReservedWords = map[string]bool{
+	"READ":       true,
+	"GROUP":      true,
+	"GROUPS":     true,
+	"RANK":       true,
+	"ORDER":      true,
+	"TABLE":      true,
+	"SELECT":     true,
+	"WHERE":      true,
+	"PRIMARY":    true,
+	"FOREIGN":    true,
+	"CONSTRAINT": true,
+	"DEFAULT":    true,
+	"VALUES":     true,
+	"LIMIT":      true,
+	"OFFSET":     true,
+	"RANGE":      true,
+	"ANALYSE":    true,
+	"ANALYZE":    true,
+}
+
+func isReserved(reserved map[string]bool, name string) bool {
+	return reserved[strings.ToUpper(name)]
+}